@@ -0,0 +1,503 @@
+// Package agent contains the observe-decide-act loop: it pulls the LLM
+// provider, browser worker, recipes and extractors out of a
+// service.Harness and drives a task to completion.
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/extract"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/llm"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/recipe"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/worker"
+)
+
+const systemPrompt = `
+You are an autonomous AI agent that operates a web browser using tools.
+
+You are NOT a chatbot.
+You do NOT provide explanations unless explicitly asked.
+
+Your objective is to complete the user’s task autonomously by:
+- observing the browser state,
+- deciding the next best action,
+- calling exactly one tool per step,
+- analyzing the result,
+- repeating until completion.
+
+Rules:
+- Do NOT hardcode URLs, selectors, button names, or page structures.
+- Infer actions only from the provided observation.
+- Do NOT assume prior knowledge of any website.
+- Ask the user only if essential information is missing.
+
+Error handling:
+- If an action fails or does not progress the task, adapt your strategy.
+- Do not repeat the same failing action more than twice.
+
+Completion:
+- If the goal is achieved or no further progress is possible, call finish().
+
+Available tools:
+- navigate(url)
+- click(element_id)
+- type(element_id, text)
+- scroll(direction)
+- wait(milliseconds)
+- ask_user(question)
+- finish(summary)
+
+Output rules (CRITICAL):
+- Respond with EXACTLY ONE valid JSON object.
+- Do NOT include explanations, markdown, or any text outside JSON.
+
+Response formats:
+
+Tool call:
+{"action":"tool","tool":"<tool_name>","arguments":{...}}
+
+Ask user:
+{"action":"ask_user","question":"<question>"}
+
+Finish:
+{"action":"finish","summary":"<short text>","results":[{"job_title":"...","company_name":"..."}]}
+- summary MUST be a string.
+- results MUST be an array of objects with job_title and company_name.
+- Do NOT put arrays/objects inside summary.
+`
+
+// MaxSteps bounds how many observe-decide-act iterations a single task
+// gets before the agent gives up.
+const MaxSteps = 15
+
+// decideLLMBudget bounds how long a single "what's the next action" call
+// is allowed to take before the agent loop gives up on this step.
+const decideLLMBudget = 60 * time.Second
+
+// recipeTimeout bounds a whole recipe invocation; individual steps inside
+// it still run under their own toolTimeout via the send closure.
+const recipeTimeout = 2 * time.Minute
+
+// runDeadline bounds total worker wall-clock time across an entire task
+// run, on top of (not instead of) the per-call toolTimeout/recipeTimeout
+// budgets: those bound any one call, this bounds the whole run, so a task
+// that keeps making individually-fast calls can't monopolize the worker
+// forever.
+const runDeadline = 10 * time.Minute
+
+// toolTimeout returns the per-step budget for a given worker tool. navigate
+// gets more slack than interaction tools since page loads can legitimately
+// take longer than a click or keystroke.
+func toolTimeout(tool string) time.Duration {
+	switch tool {
+	case "click", "type":
+		return 30 * time.Second
+	case "navigate":
+		return 90 * time.Second
+	default:
+		return 45 * time.Second
+	}
+}
+
+func obsSignature(obs map[string]interface{}) string {
+	u, _ := obs["url"].(string)
+	t, _ := obs["title"].(string)
+	vt, _ := obs["visible_text"].(string)
+
+	head := vt
+	if len(head) > 300 {
+		head = head[:300]
+	}
+	return u + "|" + t + "|" + head
+}
+
+func decideNextAction(ctx context.Context, provider llm.Provider, fullSystemPrompt, task string, observation map[string]interface{}, tools []llm.ToolSpec, emit func(string, ...interface{})) (llm.AgentAction, error) {
+	promptObj := map[string]interface{}{
+		"task":        strings.TrimSpace(task),
+		"observation": observation,
+	}
+
+	promptBytes, _ := json.MarshalIndent(promptObj, "", "  ")
+	userPrompt := string(promptBytes)
+
+	ctx, cancel := context.WithTimeout(ctx, decideLLMBudget)
+	defer cancel()
+
+	if sp, ok := provider.(llm.StreamingProvider); ok {
+		return decideNextActionStreaming(ctx, sp, fullSystemPrompt, userPrompt, tools, emit)
+	}
+
+	action, _, err := provider.Decide(ctx, fullSystemPrompt, userPrompt, tools)
+	return action, err
+}
+
+// decideNextActionStreaming drains a StreamingProvider's chunks, emitting
+// a progress line the first time a tool name becomes known so a caller
+// sees "which tool" well before that tool actually runs, and returns the
+// last chunk parsed as the real decision once the stream ends.
+func decideNextActionStreaming(ctx context.Context, provider llm.StreamingProvider, fullSystemPrompt, userPrompt string, tools []llm.ToolSpec, emit func(string, ...interface{})) (llm.AgentAction, error) {
+	chunks, errCh := provider.DecideStream(ctx, fullSystemPrompt, userPrompt, tools)
+
+	var last llm.AgentAction
+	var haveLast bool
+	var announcedTool string
+
+	for chunks != nil || errCh != nil {
+		select {
+		case raw, ok := <-chunks:
+			if !ok {
+				chunks = nil
+				continue
+			}
+			var action llm.AgentAction
+			if err := json.Unmarshal([]byte(raw), &action); err != nil {
+				continue
+			}
+			if action.Tool != "" && action.Tool != announcedTool {
+				announcedTool = action.Tool
+				emit("LLM is calling tool %q (arguments streaming in)...\n", action.Tool)
+			}
+			last = action
+			haveLast = true
+
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			if err != nil {
+				return llm.AgentAction{}, err
+			}
+
+		case <-ctx.Done():
+			return llm.AgentAction{}, ctx.Err()
+		}
+	}
+
+	if !haveLast {
+		return llm.AgentAction{}, fmt.Errorf("llm: stream produced no decision")
+	}
+	return last, nil
+}
+
+// toolSpecs returns the primitive worker tools plus one spec per loaded
+// recipe, so a Provider with real function-calling can advertise both.
+func toolSpecs(recipes *recipe.Registry) []llm.ToolSpec {
+	specs := llm.DefaultToolSpecs()
+	for _, r := range recipes.ToolSpecs() {
+		props := make(map[string]interface{}, len(r.Params))
+		for _, p := range r.Params {
+			props[p] = map[string]interface{}{"type": "string"}
+		}
+		specs = append(specs, llm.ToolSpec{
+			Name:        r.Name,
+			Description: fmt.Sprintf("Run the %s recipe.", strings.TrimPrefix(r.Name, recipe.ToolPrefix)),
+			Parameters:  map[string]interface{}{"type": "object", "properties": props},
+		})
+	}
+	return specs
+}
+
+// deps bundles the components a run pulls out of the harness.
+type deps struct {
+	llm        llm.Provider
+	worker     worker.BrowserWorker
+	recipes    *recipe.Registry
+	extractors *extract.Pipeline
+}
+
+func depsFromHarness(h *service.Harness) (deps, error) {
+	var d deps
+
+	v, ok := h.Get("llm")
+	if !ok {
+		return d, fmt.Errorf("agent: no llm provider registered in harness")
+	}
+	d.llm = v.(llm.Provider)
+
+	v, ok = h.Get("worker")
+	if !ok {
+		return d, fmt.Errorf("agent: no browser worker registered in harness")
+	}
+	d.worker = v.(worker.BrowserWorker)
+
+	v, ok = h.Get("recipes")
+	if !ok {
+		return d, fmt.Errorf("agent: no recipe registry registered in harness")
+	}
+	d.recipes = v.(*recipe.Registry)
+
+	v, ok = h.Get("extract")
+	if !ok {
+		return d, fmt.Errorf("agent: no extract pipeline registered in harness")
+	}
+	d.extractors = v.(*extract.Pipeline)
+
+	return d, nil
+}
+
+// Result is what a run produced.
+type Result struct {
+	Summary string
+	Results []llm.JobItem
+}
+
+// ErrNeedsUser is returned by RunHeadless when the agent hits an ask_user
+// step: there's no interactive user to answer it, so the run stops short
+// rather than finish with an empty Result indistinguishable from a
+// genuinely empty success.
+var ErrNeedsUser = errors.New("agent: stopped on ask_user, no interactive user available")
+
+// Run drives an interactive CLI session: it prompts for the task on in and
+// prints step-by-step progress and ask_user prompts to out.
+func Run(ctx context.Context, h *service.Harness, in io.Reader, out io.Writer) error {
+	d, err := depsFromHarness(h)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(in)
+	fmt.Fprint(out, "Enter task: ")
+	task, _ := reader.ReadString('\n')
+
+	emit := func(format string, args ...interface{}) { fmt.Fprintf(out, format, args...) }
+	ask := func(question string) (string, bool) {
+		fmt.Fprintln(out, "?", question)
+		answer, _ := reader.ReadString('\n')
+		return answer, true
+	}
+
+	// Run owns d.worker exclusively for the CLI session's one task at a
+	// time, so the run-level deadline can be armed directly on it.
+	_, err = runLoop(ctx, d, task, ask, emit, true)
+	return err
+}
+
+// RunHeadless drives a task to completion with no interactive user: an
+// ask_user step is treated as "can't proceed", ending the run with
+// ErrNeedsUser. It's meant for callers like the HTTP API that can't block
+// on stdin.
+func RunHeadless(ctx context.Context, h *service.Harness, task string, onEvent func(string)) (Result, error) {
+	d, err := depsFromHarness(h)
+	if err != nil {
+		return Result{}, err
+	}
+
+	emit := func(format string, args ...interface{}) {
+		if onEvent != nil {
+			onEvent(strings.TrimRight(fmt.Sprintf(format, args...), "\n"))
+		}
+	}
+	ask := func(question string) (string, bool) {
+		emit("ask_user (no interactive user available, stopping): %s", question)
+		return "", false
+	}
+
+	// d.worker may be the one harness-wide worker shared by other
+	// concurrently running headless tasks (see the HTTP API), so this run
+	// doesn't own it exclusively and can't arm a deadline on it directly
+	// without racing those other runs' deadlines.
+	return runLoop(ctx, d, task, ask, emit, false)
+}
+
+// runLoop is the observe-decide-act cycle shared by Run and RunHeadless.
+// ask's second return value reports whether an answer was actually
+// obtained: Run's interactive ask always does, but RunHeadless's can't, so
+// it returns false to tell runLoop to stop rather than loop forever
+// feeding an empty answer back into the task.
+//
+// exclusiveWorker tells runLoop whether it's the only caller using
+// d.worker right now (Run always is; RunHeadless isn't when the harness's
+// worker is shared across concurrent HTTP API tasks). Either way every
+// call gets capped at runDeadline via per-call ctx composition in send,
+// which is safe under sharing since it touches no worker state; an
+// exclusive caller additionally arms the worker's own SetDeadline, a
+// belt-and-suspenders cap that's only safe when nothing else is sharing
+// the worker's deadline field.
+func runLoop(ctx context.Context, d deps, task string, ask func(string) (string, bool), emit func(string, ...interface{}), exclusiveWorker bool) (Result, error) {
+	fullSystemPrompt := systemPrompt + d.recipes.ToolDescriptions()
+	tools := toolSpecs(d.recipes)
+
+	runDeadlineAt := time.Now().Add(runDeadline)
+	if exclusiveWorker {
+		d.worker.SetDeadline(runDeadlineAt)
+		defer d.worker.SetDeadline(time.Time{})
+	}
+
+	send := func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error) {
+		toolCtx, cancel := context.WithTimeout(ctx, toolTimeout(tool))
+		defer cancel()
+		toolCtx, cancel2 := context.WithDeadline(toolCtx, runDeadlineAt)
+		defer cancel2()
+		return d.worker.SendCtx(toolCtx, tool, args)
+	}
+
+	var observation map[string]interface{}
+
+	var lastAction string
+	var sameActionCount int
+
+	var lastObsSig string
+	var stagnantCount int
+	var lastExtractSig string
+
+	printedListings := false
+
+	var llmRetries int
+
+	for step := 1; step <= MaxSteps; step++ {
+		emit("\n--- STEP %d ---\n", step)
+
+		action, err := decideNextAction(ctx, d.llm, fullSystemPrompt, task, observation, tools, emit)
+		if err != nil {
+			var rateLimited *llm.ErrRateLimited
+			var transient *llm.ErrTransient
+
+			switch {
+			case errors.As(err, &rateLimited):
+				emit("LLM rate limited. Waiting %v then retrying...\n", rateLimited.RetryAfter)
+				time.Sleep(rateLimited.RetryAfter)
+				llmRetries++
+				step--
+				continue
+
+			case errors.As(err, &transient):
+				wait := llm.BackoffWithJitter(llmRetries)
+				emit("Transient LLM error (%v). Waiting %v then retrying...\n", transient.Err, wait)
+				time.Sleep(wait)
+				llmRetries++
+				step--
+				continue
+			}
+
+			emit("LLM error: %v\n", err)
+			break
+		}
+		llmRetries = 0
+		emit("LLM raw action: action=%s tool=%s args=%v question=%q summary=%q\n",
+			action.Action, action.Tool, action.Arguments, action.Question, action.Summary)
+
+		// loop-protection
+		cur := action.Action
+		if action.Action == "tool" {
+			cur += ":" + action.Tool
+
+			// не считаем scroll повтором
+			if action.Tool == "scroll" {
+				cur += ":" + fmt.Sprint(time.Now().UnixNano())
+			}
+		}
+		if cur == lastAction {
+			sameActionCount++
+		} else {
+			sameActionCount = 0
+		}
+		lastAction = cur
+
+		if sameActionCount >= 2 {
+			emit("⚠️ Detected repeated action, stopping.\n")
+			break
+		}
+
+		switch action.Action {
+		case "finish":
+			emit("✅ DONE: %s\n", action.Summary)
+
+			// если LLM вернул структурированный список
+			for i, it := range action.Results {
+				emit("%d) %s — %s\n", i+1, it.JobTitle, it.CompanyName)
+			}
+			return Result{Summary: action.Summary, Results: action.Results}, nil
+
+		case "ask_user":
+			answer, ok := ask(action.Question)
+			if !ok {
+				return Result{}, ErrNeedsUser
+			}
+			task = task + "\nUser answer: " + answer
+			continue
+
+		case "tool":
+			if action.Tool == "" {
+				emit("LLM returned tool action without tool name\n")
+				continue
+			}
+			if action.Arguments == nil {
+				action.Arguments = map[string]interface{}{}
+			}
+
+			var resp worker.Resp
+			if recipeName, ok := recipe.IsRecipeTool(action.Tool); ok {
+				recipeCtx, cancelRecipe := context.WithTimeout(ctx, recipeTimeout)
+				resp, err = d.recipes.Run(recipeCtx, send, recipeName, action.Arguments)
+				cancelRecipe()
+			} else {
+				resp, err = send(ctx, action.Tool, action.Arguments)
+			}
+			if err != nil {
+				emit("Worker timeout: %v\n", err)
+				continue
+			}
+			if resp.Status == "error" {
+				emit("Worker error: %s\n", resp.Message)
+				continue
+			}
+			observation = resp.Observation
+
+			sig := obsSignature(observation)
+			if sig == lastObsSig {
+				stagnantCount++
+			} else {
+				stagnantCount = 0
+			}
+			lastObsSig = sig
+
+			if stagnantCount >= 2 {
+				// пробуем сдвинуть страницу вместо бесконечных повторов кликов/ввода
+				_, _ = send(ctx, "scroll", map[string]interface{}{"direction": "down"})
+				time.Sleep(800 * time.Millisecond)
+				continue
+			}
+
+			// Любое изменение наблюдения — кандидат на извлечение; какой
+			// домен подходит под выдачу (и был ли вообще выполнен поиск)
+			// решает сам Pipeline через его собственное сопоставление
+			// доменов, а не литерал query-параметра здесь.
+			if !printedListings && sig != lastExtractSig {
+				lastExtractSig = sig
+				listings, err := d.extractors.Extract(ctx, observation, 3)
+				if err != nil {
+					emit("extract pipeline error: %v\n", err)
+				} else if len(listings) > 0 {
+					printedListings = true
+
+					emit("\n📌 Found listings:\n")
+					for idx, it := range listings {
+						emit("%d) %s — %s [%s]\n", idx+1, it.Title, it.Company, it.Source)
+					}
+
+					if len(listings) >= 3 {
+						emit("\n✅ DONE: collected 3 listings.\n")
+						return Result{Summary: "collected 3 listings"}, nil
+					}
+				}
+			}
+
+			time.Sleep(3 * time.Second)
+
+		default:
+			emit("Unknown action from LLM: %s\n", action.Action)
+		}
+	}
+
+	emit("⚠️ Stopped: max steps reached or agent halted.\n")
+	return Result{}, nil
+}
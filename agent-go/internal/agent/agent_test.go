@@ -0,0 +1,177 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/extract"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/llm"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/recipe"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/worker"
+)
+
+// fakeWorker is a minimal BrowserWorker that answers every SendCtx with a
+// fixed, successful observation; runLoop tests below don't exercise the
+// real stdio protocol, only the decide/act control flow around it.
+type fakeWorker struct{}
+
+func (fakeWorker) Send(action string, args map[string]interface{}) (worker.Resp, error) {
+	return worker.Resp{Status: "ok"}, nil
+}
+
+func (fakeWorker) SendCtx(ctx context.Context, action string, args map[string]interface{}) (worker.Resp, error) {
+	return worker.Resp{
+		Status:      "ok",
+		Observation: map[string]interface{}{"url": "http://example.test", "title": "t", "visible_text": "body"},
+	}, nil
+}
+
+func (fakeWorker) SetDeadline(t time.Time) {}
+func (fakeWorker) Stop()                   {}
+
+// fakeProvider always decides the same fixed action, through the plain
+// (non-streaming) llm.Provider interface.
+type fakeProvider struct {
+	action llm.AgentAction
+}
+
+func (p fakeProvider) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []llm.ToolSpec) (llm.AgentAction, llm.Usage, error) {
+	return p.action, llm.Usage{}, nil
+}
+
+// fakeProviderFunc adapts a closure to llm.Provider for tests that need a
+// decision sequence rather than one fixed action.
+type fakeProviderFunc func() llm.AgentAction
+
+func (f fakeProviderFunc) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []llm.ToolSpec) (llm.AgentAction, llm.Usage, error) {
+	return f(), llm.Usage{}, nil
+}
+
+func testDeps(t *testing.T, provider llm.Provider) deps {
+	t.Helper()
+	reg, err := recipe.LoadRegistry(t.TempDir())
+	if err != nil {
+		t.Fatalf("recipe.LoadRegistry: %v", err)
+	}
+	return deps{
+		llm:        provider,
+		worker:     fakeWorker{},
+		recipes:    reg,
+		extractors: extract.NewPipeline(nil, nil),
+	}
+}
+
+// TestRunLoopAskUserWithoutAnswerReturnsErrNeedsUser is the regression
+// test for the bug fixed where RunHeadless kept looping on an empty
+// answer instead of stopping: an ask step whose ask func reports it
+// couldn't obtain an answer (RunHeadless's ask always does) must end the
+// run with ErrNeedsUser, not a zero-value success indistinguishable from
+// a genuinely empty one.
+func TestRunLoopAskUserWithoutAnswerReturnsErrNeedsUser(t *testing.T) {
+	d := testDeps(t, fakeProvider{action: llm.AgentAction{Action: "ask_user", Question: "which city?"}})
+
+	askCalls := 0
+	ask := func(question string) (string, bool) {
+		askCalls++
+		return "", false
+	}
+	emit := func(string, ...interface{}) {}
+
+	_, err := runLoop(context.Background(), d, "find a job", ask, emit, false)
+
+	if !errors.Is(err, ErrNeedsUser) {
+		t.Fatalf("runLoop error = %v, want ErrNeedsUser", err)
+	}
+	if askCalls != 1 {
+		t.Fatalf("ask called %d times, want exactly 1", askCalls)
+	}
+}
+
+// TestRunLoopAskUserWithAnswerContinues is the interactive counterpart:
+// when ask does obtain an answer, runLoop must fold it into task and keep
+// going instead of also stopping.
+func TestRunLoopAskUserWithAnswerContinues(t *testing.T) {
+	calls := 0
+	provider := fakeProviderFunc(func() llm.AgentAction {
+		calls++
+		if calls == 1 {
+			return llm.AgentAction{Action: "ask_user", Question: "which city?"}
+		}
+		return llm.AgentAction{Action: "finish", Summary: "done"}
+	})
+	d := testDeps(t, provider)
+
+	ask := func(question string) (string, bool) { return "Moscow", true }
+	emit := func(string, ...interface{}) {}
+
+	res, err := runLoop(context.Background(), d, "find a job", ask, emit, false)
+	if err != nil {
+		t.Fatalf("runLoop error = %v, want nil", err)
+	}
+	if res.Summary != "done" {
+		t.Fatalf("res.Summary = %q, want %q", res.Summary, "done")
+	}
+	if calls != 2 {
+		t.Fatalf("provider called %d times, want 2 (ask_user then finish)", calls)
+	}
+}
+
+// fakeStreamingProvider is a llm.StreamingProvider whose DecideStream
+// sends the action through chunks the way OpenAIProvider's real
+// incremental tool-call parsing does: the caller only sees the final,
+// fully-formed AgentAction once the stream ends.
+type fakeStreamingProvider struct {
+	action llm.AgentAction
+}
+
+func (p fakeStreamingProvider) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []llm.ToolSpec) (llm.AgentAction, llm.Usage, error) {
+	return p.action, llm.Usage{}, nil
+}
+
+func (p fakeStreamingProvider) DecideStream(ctx context.Context, systemPrompt, userPrompt string, tools []llm.ToolSpec) (<-chan string, <-chan error) {
+	chunks := make(chan string, 1)
+	errCh := make(chan error, 1)
+	raw, _ := json.Marshal(p.action)
+	chunks <- string(raw)
+	close(chunks)
+	close(errCh)
+	return chunks, errCh
+}
+
+// TestRunLoopLoopProtectionStopsOnRepeatedAction is the regression test
+// for DecideStream being wired into the agent loop: an LLM that keeps
+// deciding the exact same tool call over the streaming decide path must
+// not be allowed to spin for MaxSteps; runLoop has to detect the repeat
+// and stop.
+func TestRunLoopLoopProtectionStopsOnRepeatedAction(t *testing.T) {
+	d := testDeps(t, fakeStreamingProvider{
+		action: llm.AgentAction{Action: "tool", Tool: "click", Arguments: map[string]interface{}{"element_id": "x"}},
+	})
+
+	emit := func(string, ...interface{}) {}
+	ask := func(string) (string, bool) { return "", false }
+
+	done := make(chan struct{})
+	var res Result
+	var err error
+	go func() {
+		res, err = runLoop(context.Background(), d, "find a job", ask, emit, false)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("runLoop did not return: loop protection failed to stop a repeated action")
+	}
+
+	if err != nil {
+		t.Fatalf("runLoop error = %v, want nil (loop-protection stop, not a failure)", err)
+	}
+	if res.Summary != "" {
+		t.Fatalf("res.Summary = %q, want empty (stopped by loop protection, not finish)", res.Summary)
+	}
+}
@@ -0,0 +1,55 @@
+// Package apps is the registration mechanism independently-developed
+// components use to hook themselves into startup: each component package
+// calls Register from an init() func, and main runs them all in order via
+// RunAll once the harness exists.
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+)
+
+// AppFunc builds and publishes one component into the harness.
+type AppFunc func(ctx context.Context, h *service.Harness) error
+
+type registration struct {
+	order int
+	name  string
+	fn    AppFunc
+}
+
+var (
+	mu       sync.Mutex
+	registry []registration
+)
+
+// Register records fn to run at startup, ordered ascending by order.
+// Lower orders run first, so a component that others depend on (e.g. the
+// worker) should register with a smaller order than its dependents.
+func Register(order int, name string, fn AppFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry = append(registry, registration{order: order, name: name, fn: fn})
+}
+
+// RunAll runs every registered app against h, in order, stopping at the
+// first error.
+func RunAll(ctx context.Context, h *service.Harness) error {
+	mu.Lock()
+	entries := make([]registration, len(registry))
+	copy(entries, registry)
+	mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].order < entries[j].order })
+
+	for _, e := range entries {
+		if err := e.fn(ctx, h); err != nil {
+			return fmt.Errorf("app %q: %w", e.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package extract
+
+import (
+	"context"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/llm"
+)
+
+// NewLLMFallback returns a FallbackFunc that asks provider to generalize an
+// unfamiliar page's visible text into Listings, for sites no Rule covers
+// yet. It reuses the same "finish" response shape an agent step would
+// produce, since every Provider already knows how to parse that.
+func NewLLMFallback(provider llm.Provider) FallbackFunc {
+	return func(ctx context.Context, observation map[string]interface{}, want int) ([]Listing, error) {
+		vt, _ := observation["visible_text"].(string)
+		if vt == "" {
+			return nil, nil
+		}
+		if len(vt) > 8000 {
+			vt = vt[:8000]
+		}
+
+		systemPrompt := `You generalize an unfamiliar job-listing search-results page into
+structured data. You do not know this site's layout in advance.
+
+Respond with EXACTLY ONE valid JSON object:
+{"action":"finish","summary":"...","results":[{"job_title":"...","company_name":"..."}]}
+Return up to the requested number of listings found in the page text. If
+none are present, return an empty results array.`
+
+		action, _, err := provider.Decide(ctx, systemPrompt, vt, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		listings := make([]Listing, 0, len(action.Results))
+		for _, jr := range action.Results {
+			if len(listings) >= want {
+				break
+			}
+			listings = append(listings, Listing{Title: jr.JobTitle, Company: jr.CompanyName, Source: "llm-fallback"})
+		}
+		return listings, nil
+	}
+}
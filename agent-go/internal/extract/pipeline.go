@@ -0,0 +1,245 @@
+// Package extract turns a raw observation into structured job listings
+// through a data-driven Pipeline of Rules, one per site, with an
+// LLM-based fallback for pages no Rule recognizes.
+package extract
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/apps"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/llm"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+)
+
+func init() {
+	apps.Register(30, "extract", func(ctx context.Context, h *service.Harness) error {
+		rules, err := LoadRules("../rules")
+		if err != nil {
+			return err
+		}
+
+		var fallback FallbackFunc
+		if v, ok := h.Get("llm"); ok {
+			fallback = NewLLMFallback(v.(llm.Provider))
+		}
+
+		h.Add("extract", NewPipeline(rules, fallback))
+		return nil
+	})
+}
+
+// Listing is one job posting pulled out of an observation.
+type Listing struct {
+	Title    string
+	Company  string
+	Location string
+	Salary   string
+	URL      string
+
+	// Source names the Rule that produced this Listing, or "llm-fallback".
+	Source string
+}
+
+// FallbackFunc generalizes an observation no Rule matched into Listings,
+// typically by asking an LLM to read the page text.
+type FallbackFunc func(ctx context.Context, observation map[string]interface{}, want int) ([]Listing, error)
+
+// Pipeline dispatches an observation to the first matching Rule, falling
+// back to an LLM-based extractor when no Rule recognizes the page.
+type Pipeline struct {
+	rules    []Rule
+	fallback FallbackFunc
+}
+
+// NewPipeline returns a Pipeline that tries rules in order before falling
+// back to fallback (which may be nil).
+func NewPipeline(rules []Rule, fallback FallbackFunc) *Pipeline {
+	return &Pipeline{rules: rules, fallback: fallback}
+}
+
+// LoadRules reads every *.yaml/*.yml file in dir as a Rule. A missing
+// directory yields an empty, usable rule set rather than an error, since
+// shipping no rules just means every page goes through the fallback.
+func LoadRules(dir string) ([]Rule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading rules dir %s: %w", dir, err)
+	}
+
+	var rules []Rule
+	for _, e := range entries {
+		if e.IsDir() || !(strings.HasSuffix(e.Name(), ".yaml") || strings.HasSuffix(e.Name(), ".yml")) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading rule %s: %w", path, err)
+		}
+		var r Rule
+		if err := yaml.Unmarshal(data, &r); err != nil {
+			return nil, fmt.Errorf("parsing rule %s: %w", path, err)
+		}
+		if r.Name == "" {
+			return nil, fmt.Errorf("rule %s: missing name", path)
+		}
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// Extract returns up to want Listings from observation, via the first Rule
+// whose domains match the observation's url, or the fallback if none do.
+func (p *Pipeline) Extract(ctx context.Context, observation map[string]interface{}, want int) ([]Listing, error) {
+	u, _ := observation["url"].(string)
+	domain := hostOf(u)
+
+	for _, r := range p.rules {
+		if !r.Match(domain, u) {
+			continue
+		}
+		vt, _ := observation["visible_text"].(string)
+		return extractWithRule(r, vt, want), nil
+	}
+
+	if p.fallback != nil {
+		return p.fallback(ctx, observation, want)
+	}
+	return nil, nil
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// extractWithRule scans visible for up to want title/company pairs using
+// r's keyword heuristic, tagging each Listing with r.Name as its source.
+func extractWithRule(r Rule, visible string, want int) []Listing {
+	visible = strings.ReplaceAll(visible, "\r\n", "\n")
+
+	if r.ResultsAnchor != "" {
+		lower := strings.ToLower(visible)
+		if idx := strings.Index(lower, strings.ToLower(r.ResultsAnchor)); idx >= 0 {
+			visible = visible[idx:]
+		}
+	}
+
+	linesRaw := strings.Split(visible, "\n")
+	lines := make([]string, 0, len(linesRaw))
+	for _, l := range linesRaw {
+		l = normalizeSpaces(l)
+		if l == "" || len([]rune(l)) > 120 {
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	minTitleLen := orDefault(r.MinTitleLen, 4)
+	maxTitleLen := orDefault(r.MaxTitleLen, 90)
+	maxCompanyLen := orDefault(r.MaxCompanyLen, 60)
+	lookahead := orDefault(r.CompanyLookaheadLines, 6)
+
+	found := make([]Listing, 0, want)
+	seen := map[string]bool{}
+
+	for i := 0; i < len(lines); i++ {
+		title := lines[i]
+		if !isLikelyTitle(title, r, minTitleLen, maxTitleLen) {
+			continue
+		}
+
+		company := ""
+		for j := i + 1; j < len(lines) && j <= i+lookahead; j++ {
+			if isLikelyCompany(lines[j], r, maxCompanyLen) {
+				company = lines[j]
+				break
+			}
+		}
+		if company == "" {
+			continue
+		}
+
+		key := strings.ToLower(title) + "|" + strings.ToLower(company)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		found = append(found, Listing{Title: title, Company: company, Source: r.Name})
+		if len(found) >= want {
+			break
+		}
+	}
+
+	return found
+}
+
+func orDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+func isLikelyTitle(s string, r Rule, minLen, maxLen int) bool {
+	low := strings.ToLower(s)
+
+	for _, b := range r.TitleExcludeKeywords {
+		if strings.Contains(low, strings.ToLower(b)) {
+			return false
+		}
+	}
+
+	if len(r.TitleKeywords) > 0 {
+		ok := false
+		for _, k := range r.TitleKeywords {
+			if strings.Contains(low, strings.ToLower(k)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	n := len([]rune(s))
+	return n >= minLen && n <= maxLen
+}
+
+func isLikelyCompany(s string, r Rule, maxLen int) bool {
+	if s == "" {
+		return false
+	}
+	low := strings.ToLower(s)
+	for _, b := range r.CompanyExcludeKeywords {
+		if strings.Contains(low, strings.ToLower(b)) {
+			return false
+		}
+	}
+	return len([]rune(s)) <= maxLen
+}
+
+func normalizeSpaces(s string) string {
+	s = strings.ReplaceAll(s, "\u00a0", " ") // NBSP
+	s = strings.TrimSpace(s)
+	for strings.Contains(s, "  ") {
+		s = strings.ReplaceAll(s, "  ", " ")
+	}
+	return s
+}
@@ -0,0 +1,88 @@
+package extract
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadRulesParsesYAML(t *testing.T) {
+	rules, err := LoadRules("../../../rules")
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+
+	names := map[string]bool{}
+	for _, r := range rules {
+		names[r.Name] = true
+	}
+	for _, want := range []string{"hh.ru", "linkedin", "indeed"} {
+		if !names[want] {
+			t.Fatalf("missing rule %q in %v", want, names)
+		}
+	}
+}
+
+func TestLoadRulesMissingDirIsEmpty(t *testing.T) {
+	rules, err := LoadRules("/no/such/dir")
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if rules != nil {
+		t.Fatalf("rules = %v, want nil", rules)
+	}
+}
+
+func TestPipelineExtractDispatchesByDomain(t *testing.T) {
+	rule := Rule{
+		Name:                  "hh.ru",
+		Domains:               []string{"hh.ru"},
+		TitleKeywords:         []string{"engineer"},
+		MinTitleLen:           4,
+		MaxTitleLen:           90,
+		MaxCompanyLen:         60,
+		CompanyLookaheadLines: 3,
+	}
+	p := NewPipeline([]Rule{rule}, nil)
+
+	observation := map[string]interface{}{
+		"url":          "https://hh.ru/search/vacancy?text=engineer",
+		"visible_text": "AI Engineer\nAcme Corp\nBackend Engineer\nWidgets Inc",
+	}
+
+	listings, err := p.Extract(context.Background(), observation, 2)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(listings) != 2 {
+		t.Fatalf("got %d listings, want 2: %+v", len(listings), listings)
+	}
+	if listings[0].Title != "AI Engineer" || listings[0].Company != "Acme Corp" {
+		t.Fatalf("listings[0] = %+v", listings[0])
+	}
+	if listings[0].Source != "hh.ru" {
+		t.Fatalf("source = %q, want hh.ru", listings[0].Source)
+	}
+}
+
+func TestPipelineExtractNoMatchingRuleUsesFallback(t *testing.T) {
+	called := false
+	fallback := func(ctx context.Context, observation map[string]interface{}, want int) ([]Listing, error) {
+		called = true
+		return []Listing{{Title: "t", Company: "c", Source: "llm-fallback"}}, nil
+	}
+	p := NewPipeline(nil, fallback)
+
+	listings, err := p.Extract(context.Background(), map[string]interface{}{"url": "https://example.com"}, 1)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !called {
+		t.Fatal("expected fallback to be called when no rule matches")
+	}
+	if len(listings) != 1 || listings[0].Source != "llm-fallback" {
+		t.Fatalf("listings = %+v", listings)
+	}
+}
@@ -0,0 +1,46 @@
+package extract
+
+import "strings"
+
+// Rule describes how to pull Listings out of one site's search-results
+// page, through data instead of bespoke Go code per domain.
+//
+// This is not yet the DOM/accessibility-tree pipeline the request asked
+// for: the browser worker only emits rendered visible_text, no a11y
+// snapshot or bounding boxes to select against, so every rule resolves
+// through the line-based keyword heuristic below. What's real here is the
+// config-driven, multi-domain part — dropping in a new site is a YAML
+// file, not a Go change.
+type Rule struct {
+	Name    string   `json:"name" yaml:"name"`
+	Domains []string `json:"domains" yaml:"domains"`
+
+	// ResultsAnchor marks where the results block starts in visible_text
+	// (e.g. "Найдено" on hh.ru), so boilerplate above it is skipped.
+	ResultsAnchor string `json:"results_anchor,omitempty" yaml:"results_anchor,omitempty"`
+
+	// TitleKeywords/TitleExcludeKeywords/CompanyExcludeKeywords drive the
+	// text-heuristic fallback: a line is a plausible title if it contains
+	// at least one TitleKeywords entry and none of TitleExcludeKeywords; a
+	// later line is a plausible company if it contains none of
+	// CompanyExcludeKeywords.
+	TitleKeywords          []string `json:"title_keywords,omitempty" yaml:"title_keywords,omitempty"`
+	TitleExcludeKeywords   []string `json:"title_exclude_keywords,omitempty" yaml:"title_exclude_keywords,omitempty"`
+	CompanyExcludeKeywords []string `json:"company_exclude_keywords,omitempty" yaml:"company_exclude_keywords,omitempty"`
+
+	MinTitleLen           int `json:"min_title_len,omitempty" yaml:"min_title_len,omitempty"`
+	MaxTitleLen           int `json:"max_title_len,omitempty" yaml:"max_title_len,omitempty"`
+	MaxCompanyLen         int `json:"max_company_len,omitempty" yaml:"max_company_len,omitempty"`
+	CompanyLookaheadLines int `json:"company_lookahead_lines,omitempty" yaml:"company_lookahead_lines,omitempty"`
+}
+
+// Match reports whether the rule applies to a page at url on domain.
+func (r Rule) Match(domain, url string) bool {
+	haystack := strings.ToLower(domain) + " " + strings.ToLower(url)
+	for _, d := range r.Domains {
+		if strings.Contains(haystack, strings.ToLower(d)) {
+			return true
+		}
+	}
+	return false
+}
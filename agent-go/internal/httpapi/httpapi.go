@@ -0,0 +1,242 @@
+// Package httpapi is an optional app exposing the agent over HTTP:
+// POST /tasks to start a run, GET /tasks/{id} to poll it, and
+// GET /tasks/{id}/events to stream its step log over SSE. It only starts
+// when HTTP_API_ADDR is set, since the primary interface is the CLI.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/agent"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/apps"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+)
+
+func init() {
+	apps.Register(90, "httpapi", func(ctx context.Context, h *service.Harness) error {
+		addr := os.Getenv("HTTP_API_ADDR")
+		if addr == "" {
+			return nil
+		}
+
+		srv := NewServer(h)
+		go func() {
+			if err := http.ListenAndServe(addr, srv.Handler()); err != nil {
+				fmt.Fprintln(os.Stderr, "httpapi: listen failed:", err)
+			}
+		}()
+		return nil
+	})
+}
+
+// task tracks one agent run and fans its step log out to subscribers.
+type task struct {
+	id string
+
+	mu     sync.Mutex
+	status string // "running", "done", "error"
+	result agent.Result
+	errMsg string
+	log    []string
+	subs   []chan string
+}
+
+func newTask(id string) *task {
+	return &task{id: id, status: "running"}
+}
+
+func (t *task) emit(line string) {
+	t.mu.Lock()
+	t.log = append(t.log, line)
+	subs := make([]chan string, len(t.subs))
+	copy(subs, t.subs)
+	t.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+func (t *task) finish(result agent.Result, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.status = "error"
+		t.errMsg = err.Error()
+		return
+	}
+	t.status = "done"
+	t.result = result
+}
+
+func (t *task) subscribe() chan string {
+	ch := make(chan string, 32)
+	t.mu.Lock()
+	t.subs = append(t.subs, ch)
+	t.mu.Unlock()
+	return ch
+}
+
+func (t *task) unsubscribe(ch chan string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, s := range t.subs {
+		if s == ch {
+			t.subs = append(t.subs[:i], t.subs[i+1:]...)
+			break
+		}
+	}
+}
+
+func (t *task) snapshot() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := map[string]interface{}{
+		"id":     t.id,
+		"status": t.status,
+	}
+	if t.status == "done" {
+		out["summary"] = t.result.Summary
+		out["results"] = t.result.Results
+	}
+	if t.status == "error" {
+		out["error"] = t.errMsg
+	}
+	return out
+}
+
+// Server is the HTTP front end for running and polling agent tasks.
+type Server struct {
+	h *service.Harness
+
+	mu    sync.Mutex
+	tasks map[string]*task
+	next  int64
+}
+
+// NewServer returns a Server that runs tasks against h's registered
+// components.
+func NewServer(h *service.Harness) *Server {
+	return &Server{h: h, tasks: map[string]*task{}}
+}
+
+// Handler returns the HTTP routes this server serves.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tasks", s.handleTasks)
+	mux.HandleFunc("/tasks/", s.handleTaskByID)
+	return mux
+}
+
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(body.Task) == "" {
+		http.Error(w, "task is required", http.StatusBadRequest)
+		return
+	}
+
+	t := s.newTask()
+	go s.runTask(t, body.Task)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"id": t.id})
+}
+
+func (s *Server) handleTaskByID(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/tasks/")
+	id, sub, hasSub := strings.Cut(rest, "/")
+
+	t, ok := s.get(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if hasSub && sub == "events" {
+		s.streamEvents(w, r, t)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.snapshot())
+}
+
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, t *task) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := t.subscribe()
+	defer t.unsubscribe(ch)
+
+	for {
+		select {
+		case line := <-ch:
+			writeSSEEvent(w, line)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeSSEEvent frames line as one SSE event. Event log lines can contain
+// embedded newlines (e.g. the "--- STEP N ---" separator), and SSE treats
+// a line with no "data: " prefix as framing noise rather than part of the
+// event, so every line of a multi-line chunk needs its own prefix.
+func writeSSEEvent(w io.Writer, line string) {
+	for _, part := range strings.Split(line, "\n") {
+		fmt.Fprintf(w, "data: %s\n", part)
+	}
+	fmt.Fprint(w, "\n")
+}
+
+func (s *Server) newTask() *task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	id := strconv.FormatInt(s.next, 10)
+	t := newTask(id)
+	s.tasks[id] = t
+	return t
+}
+
+func (s *Server) get(id string) (*task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[id]
+	return t, ok
+}
+
+func (s *Server) runTask(t *task, taskText string) {
+	result, err := agent.RunHeadless(context.Background(), s.h, taskText, t.emit)
+	t.finish(result, err)
+}
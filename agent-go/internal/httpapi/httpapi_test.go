@@ -0,0 +1,28 @@
+package httpapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteSSEEventPrefixesEveryLine(t *testing.T) {
+	var b strings.Builder
+	writeSSEEvent(&b, "\n--- STEP 1 ---\nnavigated to https://example.com")
+
+	got := b.String()
+	want := "data: \ndata: --- STEP 1 ---\ndata: navigated to https://example.com\n\n"
+	if got != want {
+		t.Fatalf("writeSSEEvent output = %q, want %q", got, want)
+	}
+}
+
+func TestWriteSSEEventSingleLine(t *testing.T) {
+	var b strings.Builder
+	writeSSEEvent(&b, "done")
+
+	got := b.String()
+	want := "data: done\n\n"
+	if got != want {
+		t.Fatalf("writeSSEEvent output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// GeminiProvider calls the Gemini generateContent API in JSON mode. It has
+// no real function-calling support, so tools are rendered into the system
+// prompt and the response is parsed as prose-wrapped JSON.
+type GeminiProvider struct{}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+
+	UsageMetadata *struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata,omitempty"`
+
+	Error *struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error,omitempty"`
+}
+
+func (p *GeminiProvider) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (AgentAction, Usage, error) {
+	raw, usage, err := p.call(ctx, "generateContent", systemPrompt+RenderToolDescriptions(tools), userPrompt)
+	if err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+
+	action, err := parseJSONAction(raw)
+	return action, usage, err
+}
+
+func (p *GeminiProvider) call(ctx context.Context, endpoint, systemPrompt, userPrompt string) (string, Usage, error) {
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		return "", Usage{}, fmt.Errorf("GEMINI_API_KEY is not set")
+	}
+
+	model := os.Getenv("GEMINI_MODEL")
+	if model == "" {
+		model = "gemini-2.5-flash"
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:%s?key=%s", model, endpoint, apiKey)
+
+	payload := map[string]interface{}{
+		"systemInstruction": map[string]interface{}{
+			"parts": []map[string]string{
+				{"text": systemPrompt},
+			},
+		},
+		"contents": []map[string]interface{}{
+			{
+				"role": "user",
+				"parts": []map[string]string{
+					{"text": userPrompt},
+				},
+			},
+		},
+		"generationConfig": map[string]interface{}{
+			"temperature":      0,
+			"maxOutputTokens":  800,
+			"responseMimeType": "application/json",
+		},
+	}
+
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return "", Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, &ErrTransient{Err: err}
+	}
+	defer resp.Body.Close()
+
+	var out geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", Usage{}, err
+	}
+
+	if out.Error != nil {
+		if resp.StatusCode == http.StatusTooManyRequests || out.Error.Status == "RESOURCE_EXHAUSTED" {
+			wait, ok := parseRetryAfter(out.Error.Message)
+			if !ok {
+				wait = 60 * time.Second
+			}
+			return "", Usage{}, &ErrRateLimited{RetryAfter: wait}
+		}
+		if resp.StatusCode >= 500 {
+			return "", Usage{}, &ErrTransient{Err: fmt.Errorf("gemini: %s (%s)", out.Error.Message, out.Error.Status)}
+		}
+		return "", Usage{}, fmt.Errorf("Gemini API error: %s (%s)", out.Error.Message, out.Error.Status)
+	}
+
+	if len(out.Candidates) == 0 || len(out.Candidates[0].Content.Parts) == 0 {
+		return "", Usage{}, fmt.Errorf("empty Gemini response")
+	}
+
+	var usage Usage
+	if out.UsageMetadata != nil {
+		usage = Usage{
+			PromptTokens:     out.UsageMetadata.PromptTokenCount,
+			CompletionTokens: out.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:      out.UsageMetadata.TotalTokenCount,
+		}
+	}
+
+	return strings.TrimSpace(out.Candidates[0].Content.Parts[0].Text), usage, nil
+}
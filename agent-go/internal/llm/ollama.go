@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// OllamaProvider talks to a local Ollama /api/chat endpoint. Like Gemini,
+// it has no real function-calling support, so tools are rendered into the
+// system prompt and the response is parsed as prose-wrapped JSON.
+type OllamaProvider struct{}
+
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error,omitempty"`
+}
+
+func (p *OllamaProvider) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (AgentAction, Usage, error) {
+	raw, usage, err := p.call(ctx, systemPrompt+RenderToolDescriptions(tools), userPrompt)
+	if err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+
+	action, err := parseJSONAction(raw)
+	return action, usage, err
+}
+
+func (p *OllamaProvider) call(ctx context.Context, systemPrompt, userPrompt string) (string, Usage, error) {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		host = "http://localhost:11434"
+	}
+	model := os.Getenv("OLLAMA_MODEL")
+	if model == "" {
+		model = "llama3.1"
+	}
+
+	payload := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "system", "content": systemPrompt},
+			{"role": "user", "content": userPrompt},
+		},
+		"format": "json",
+		"stream": false,
+		"options": map[string]interface{}{
+			"temperature": 0,
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", host+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return "", Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 120 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", Usage{}, &ErrTransient{Err: err}
+	}
+	defer resp.Body.Close()
+
+	var out ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", Usage{}, err
+	}
+
+	if out.Error != "" {
+		if resp.StatusCode >= 500 {
+			return "", Usage{}, &ErrTransient{Err: fmt.Errorf("ollama: %s", out.Error)}
+		}
+		return "", Usage{}, fmt.Errorf("Ollama API error: %s", out.Error)
+	}
+	if out.Message.Content == "" {
+		return "", Usage{}, fmt.Errorf("empty Ollama response")
+	}
+
+	usage := Usage{PromptTokens: out.PromptEvalCount, CompletionTokens: out.EvalCount, TotalTokens: out.PromptEvalCount + out.EvalCount}
+	return out.Message.Content, usage, nil
+}
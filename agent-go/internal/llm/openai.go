@@ -0,0 +1,358 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAIProvider talks to an OpenAI-compatible /chat/completions endpoint
+// using real tool/function-calling, so AgentAction comes from a
+// structured tool_calls response instead of regexing braces out of prose.
+type OpenAIProvider struct{}
+
+type openAITool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Parameters  map[string]interface{} `json:"parameters"`
+	} `json:"function"`
+}
+
+func toOpenAITools(tools []ToolSpec) []openAITool {
+	out := make([]openAITool, len(tools))
+	for i, t := range tools {
+		out[i].Type = "function"
+		out[i].Function.Name = t.Name
+		out[i].Function.Description = t.Description
+		out[i].Function.Parameters = t.Parameters
+	}
+	return out
+}
+
+type openAIToolCall struct {
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error,omitempty"`
+}
+
+func (p *OpenAIProvider) config() (baseURL, apiKey, model string, err error) {
+	apiKey = os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return "", "", "", fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	baseURL = os.Getenv("OPENAI_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	model = os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	return baseURL, apiKey, model, nil
+}
+
+func (p *OpenAIProvider) requestBody(systemPrompt, userPrompt string, tools []ToolSpec, model string) ([]byte, error) {
+	return p.buildRequestBody(systemPrompt, userPrompt, tools, model, false)
+}
+
+func (p *OpenAIProvider) buildRequestBody(systemPrompt, userPrompt string, tools []ToolSpec, model string, stream bool) ([]byte, error) {
+	body := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "system", "content": systemPrompt},
+			{"role": "user", "content": userPrompt},
+		},
+		"temperature": 0,
+		"stream":      stream,
+	}
+	// Omit tools/tool_choice entirely when there are none to offer: the API
+	// rejects tool_choice without a non-empty tools array.
+	if len(tools) > 0 {
+		body["tools"] = toOpenAITools(tools)
+		body["tool_choice"] = "auto"
+	}
+	return json.Marshal(body)
+}
+
+func (p *OpenAIProvider) Decide(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (AgentAction, Usage, error) {
+	baseURL, apiKey, model, err := p.config()
+	if err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+
+	body, err := p.requestBody(systemPrompt, userPrompt, tools, model)
+	if err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return AgentAction{}, Usage{}, &ErrTransient{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait := 60 * time.Second
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				wait = time.Duration(secs) * time.Second
+			}
+		}
+		return AgentAction{}, Usage{}, &ErrRateLimited{RetryAfter: wait}
+	}
+
+	var out openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return AgentAction{}, Usage{}, err
+	}
+
+	if out.Error != nil {
+		if resp.StatusCode >= 500 {
+			return AgentAction{}, Usage{}, &ErrTransient{Err: fmt.Errorf("openai: %s", out.Error.Message)}
+		}
+		return AgentAction{}, Usage{}, fmt.Errorf("OpenAI API error: %s (%s)", out.Error.Message, out.Error.Type)
+	}
+
+	action, err := actionFromOpenAIResponse(out)
+	usage := Usage{PromptTokens: out.Usage.PromptTokens, CompletionTokens: out.Usage.CompletionTokens, TotalTokens: out.Usage.TotalTokens}
+	return action, usage, err
+}
+
+func actionFromOpenAIResponse(out openAIResponse) (AgentAction, error) {
+	if len(out.Choices) == 0 {
+		return AgentAction{}, fmt.Errorf("empty OpenAI response")
+	}
+
+	msg := out.Choices[0].Message
+	if len(msg.ToolCalls) == 0 {
+		// No function call: fall back to parsing the content as JSON, the
+		// same contract Gemini/Ollama use when prompted without tools (e.g.
+		// extract's LLM fallback, which always calls Decide with tools=nil).
+		return parseJSONAction(msg.Content)
+	}
+
+	return actionFromToolCall(msg.ToolCalls[0])
+}
+
+func actionFromToolCall(call openAIToolCall) (AgentAction, error) {
+	var args map[string]interface{}
+	if call.Function.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			return AgentAction{}, fmt.Errorf("failed to parse tool_call arguments: %v, raw=%s", err, call.Function.Arguments)
+		}
+	}
+
+	switch call.Function.Name {
+	case "finish":
+		action := AgentAction{Action: "finish"}
+		if s, ok := args["summary"].(string); ok {
+			action.Summary = s
+		}
+		if rs, ok := args["results"].([]interface{}); ok {
+			for _, r := range rs {
+				m, ok := r.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				jt, _ := m["job_title"].(string)
+				cn, _ := m["company_name"].(string)
+				action.Results = append(action.Results, JobItem{JobTitle: jt, CompanyName: cn})
+			}
+		}
+		return action, nil
+
+	case "ask_user":
+		q, _ := args["question"].(string)
+		return AgentAction{Action: "ask_user", Question: q}, nil
+
+	default:
+		return AgentAction{Action: "tool", Tool: call.Function.Name, Arguments: args}, nil
+	}
+}
+
+// openAIStreamChunk is one "data: {...}" frame of a chat completion
+// stream: choices[0].delta carries whatever is new since the last chunk,
+// rather than the accumulated message actionFromOpenAIResponse expects.
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// DecideStream issues the same request as Decide with "stream": true and
+// reassembles the tool_calls deltas as they arrive, pushing a snapshot to
+// chunks as soon as the tool name is known and again once its arguments
+// are complete, so a caller can react to "which tool" well before
+// "with what arguments" is known.
+func (p *OpenAIProvider) DecideStream(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (<-chan string, <-chan error) {
+	chunks := make(chan string, 4)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		defer close(errCh)
+
+		baseURL, apiKey, model, err := p.config()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		body, err := p.buildRequestBody(systemPrompt, userPrompt, tools, model, true)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/chat/completions", bytes.NewReader(body))
+		if err != nil {
+			errCh <- err
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		req.Header.Set("Accept", "text/event-stream")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			errCh <- &ErrTransient{Err: err}
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := 60 * time.Second
+			if ra := resp.Header.Get("Retry-After"); ra != "" {
+				if secs, err := strconv.Atoi(ra); err == nil {
+					wait = time.Duration(secs) * time.Second
+				}
+			}
+			errCh <- &ErrRateLimited{RetryAfter: wait}
+			return
+		}
+		if resp.StatusCode >= 500 {
+			errCh <- &ErrTransient{Err: fmt.Errorf("openai: stream request failed with status %d", resp.StatusCode)}
+			return
+		}
+		if resp.StatusCode >= 400 {
+			errCh <- fmt.Errorf("openai: stream request failed with status %d", resp.StatusCode)
+			return
+		}
+
+		var toolName string
+		var argsBuilder strings.Builder
+		var contentBuilder strings.Builder
+		announced := false
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data := strings.TrimPrefix(scanner.Text(), "data: ")
+			if data == "" || data == "[DONE]" {
+				continue
+			}
+
+			var delta openAIStreamChunk
+			if err := json.Unmarshal([]byte(data), &delta); err != nil || len(delta.Choices) == 0 {
+				continue
+			}
+
+			d := delta.Choices[0].Delta
+			if len(d.ToolCalls) > 0 {
+				tc := d.ToolCalls[0]
+				if tc.Function.Name != "" {
+					toolName = tc.Function.Name
+				}
+				argsBuilder.WriteString(tc.Function.Arguments)
+
+				if toolName != "" && !announced {
+					announced = true
+					chunks <- marshalAction(AgentAction{Action: "tool", Tool: toolName})
+				}
+			}
+			contentBuilder.WriteString(d.Content)
+		}
+		if err := scanner.Err(); err != nil {
+			errCh <- err
+			return
+		}
+
+		if toolName != "" {
+			action, err := actionFromToolCall(openAIToolCall{Function: struct {
+				Name      string `json:"name"`
+				Arguments string `json:"arguments"`
+			}{Name: toolName, Arguments: argsBuilder.String()}})
+			if err != nil {
+				errCh <- err
+				return
+			}
+			chunks <- marshalAction(action)
+			return
+		}
+
+		// No tool call this turn: same content-as-JSON fallback Decide uses.
+		action, err := parseJSONAction(contentBuilder.String())
+		if err != nil {
+			errCh <- err
+			return
+		}
+		chunks <- marshalAction(action)
+	}()
+
+	return chunks, errCh
+}
+
+// marshalAction JSON-encodes action for a DecideStream chunk. Marshal of
+// a well-formed AgentAction cannot fail, so an error here can only mean a
+// caller passed something json.Marshal rejects outright (e.g. a NaN in a
+// tool argument); reporting it as the empty object is fine since it'll
+// simply fail to unmarshal back into a useful AgentAction downstream.
+func marshalAction(action AgentAction) string {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActionFromToolCallFinish(t *testing.T) {
+	call := openAIToolCall{}
+	call.Function.Name = "finish"
+	call.Function.Arguments = `{"summary":"done","results":[{"job_title":"Engineer","company_name":"Acme"}]}`
+
+	action, err := actionFromToolCall(call)
+	if err != nil {
+		t.Fatalf("actionFromToolCall: %v", err)
+	}
+	if action.Action != "finish" || action.Summary != "done" {
+		t.Fatalf("action = %+v", action)
+	}
+	if len(action.Results) != 1 || action.Results[0].CompanyName != "Acme" {
+		t.Fatalf("results = %+v", action.Results)
+	}
+}
+
+func TestActionFromToolCallAskUser(t *testing.T) {
+	call := openAIToolCall{}
+	call.Function.Name = "ask_user"
+	call.Function.Arguments = `{"question":"which city?"}`
+
+	action, err := actionFromToolCall(call)
+	if err != nil {
+		t.Fatalf("actionFromToolCall: %v", err)
+	}
+	if action.Action != "ask_user" || action.Question != "which city?" {
+		t.Fatalf("action = %+v", action)
+	}
+}
+
+func TestActionFromToolCallPrimitive(t *testing.T) {
+	call := openAIToolCall{}
+	call.Function.Name = "navigate"
+	call.Function.Arguments = `{"url":"https://example.com"}`
+
+	action, err := actionFromToolCall(call)
+	if err != nil {
+		t.Fatalf("actionFromToolCall: %v", err)
+	}
+	if action.Action != "tool" || action.Tool != "navigate" {
+		t.Fatalf("action = %+v", action)
+	}
+	if action.Arguments["url"] != "https://example.com" {
+		t.Fatalf("arguments = %v", action.Arguments)
+	}
+}
+
+func TestActionFromToolCallInvalidArgumentsErrors(t *testing.T) {
+	call := openAIToolCall{}
+	call.Function.Name = "navigate"
+	call.Function.Arguments = `{not json`
+
+	if _, err := actionFromToolCall(call); err == nil {
+		t.Fatal("expected an error for invalid arguments JSON")
+	}
+}
+
+func TestDecideStreamAnnouncesToolBeforeArgumentsComplete(t *testing.T) {
+	frames := []string{
+		`{"choices":[{"delta":{"tool_calls":[{"function":{"name":"navigate","arguments":"{\"url\":"}}]}}]}`,
+		`{"choices":[{"delta":{"tool_calls":[{"function":{"name":"","arguments":"\"https://example.com\"}"}}]}}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for _, f := range frames {
+			fmt.Fprintf(w, "data: %s\n\n", f)
+			flusher.Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	t.Setenv("OPENAI_BASE_URL", server.URL)
+
+	p := &OpenAIProvider{}
+	chunks, errCh := p.DecideStream(context.Background(), "system", "user", nil)
+
+	var seen []AgentAction
+	for chunks != nil || errCh != nil {
+		select {
+		case raw, ok := <-chunks:
+			if !ok {
+				chunks = nil
+				continue
+			}
+			var action AgentAction
+			if err := json.Unmarshal([]byte(raw), &action); err != nil {
+				t.Fatalf("unmarshal chunk %q: %v", raw, err)
+			}
+			seen = append(seen, action)
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			if err != nil {
+				t.Fatalf("DecideStream: %v", err)
+			}
+		}
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("got %d chunks, want 2: %+v", len(seen), seen)
+	}
+	if seen[0].Tool != "navigate" || seen[0].Arguments != nil {
+		t.Fatalf("first chunk = %+v, want tool announced with no arguments yet", seen[0])
+	}
+	if seen[1].Tool != "navigate" || seen[1].Arguments["url"] != "https://example.com" {
+		t.Fatalf("final chunk = %+v", seen[1])
+	}
+}
+
+func TestActionFromOpenAIResponseNoToolCallsFallsBackToJSON(t *testing.T) {
+	out := openAIResponse{}
+	out.Choices = []struct {
+		Message struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"message"`
+	}{
+		{Message: struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		}{Content: `{"action":"finish","summary":"ok"}`}},
+	}
+
+	action, err := actionFromOpenAIResponse(out)
+	if err != nil {
+		t.Fatalf("actionFromOpenAIResponse: %v", err)
+	}
+	if action.Action != "finish" || action.Summary != "ok" {
+		t.Fatalf("action = %+v", action)
+	}
+}
@@ -0,0 +1,301 @@
+// Package llm abstracts "decide the next step" behind a Provider
+// interface so the agent loop isn't wired to one vendor, one URL shape,
+// or one response format.
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/apps"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+)
+
+func init() {
+	apps.Register(10, "llm", func(ctx context.Context, h *service.Harness) error {
+		provider, err := NewProviderFromEnv()
+		if err != nil {
+			return err
+		}
+		h.Add("llm", provider)
+		return nil
+	})
+}
+
+// ToolSpec describes one callable tool (primitive or recipe) so a Provider
+// that supports real function-calling (OpenAI) can advertise it as such,
+// while a prompt-only provider (Gemini, Ollama) can render it into the
+// system prompt instead.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{} // JSON Schema object
+}
+
+// DefaultToolSpecs lists the primitive worker tools every provider needs
+// to know about; callers append recipe-derived specs on top.
+func DefaultToolSpecs() []ToolSpec {
+	return []ToolSpec{
+		{Name: "navigate", Description: "Navigate the browser to a URL.", Parameters: objSchema("url")},
+		{Name: "click", Description: "Click an element by id.", Parameters: objSchema("element_id")},
+		{Name: "type", Description: "Type text into an element by id.", Parameters: objSchema("element_id", "text")},
+		{Name: "scroll", Description: "Scroll the page.", Parameters: objSchema("direction")},
+		{Name: "wait", Description: "Wait for a number of milliseconds.", Parameters: objSchema("milliseconds")},
+		{Name: "ask_user", Description: "Ask the user a clarifying question.", Parameters: objSchema("question")},
+		{Name: "finish", Description: "Finish the task with a summary and optional results.", Parameters: objSchema("summary")},
+	}
+}
+
+func objSchema(props ...string) map[string]interface{} {
+	p := make(map[string]interface{}, len(props))
+	for _, name := range props {
+		p[name] = map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{"type": "object", "properties": p}
+}
+
+// RenderToolDescriptions renders tools as plain text for providers that
+// don't support real function-calling and rely on the system prompt.
+func RenderToolDescriptions(tools []ToolSpec) string {
+	if len(tools) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nTools:\n")
+	for _, t := range tools {
+		b.WriteString(fmt.Sprintf("- %s: %s\n", t.Name, t.Description))
+	}
+	return b.String()
+}
+
+// AgentAction is what the LLM decided to do next.
+type AgentAction struct {
+	Action    string                 `json:"action"`
+	Tool      string                 `json:"tool,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Question  string                 `json:"question,omitempty"`
+
+	// summary может прийти строкой или массивом (из-за модели)
+	SummaryRaw json.RawMessage `json:"summary,omitempty"`
+
+	// нормальный структурированный результат
+	Results []JobItem `json:"results,omitempty"`
+
+	Summary string `json:"-"`
+}
+
+// JobItem is one structured result the LLM reports on finish().
+type JobItem struct {
+	JobTitle    string `json:"job_title"`
+	CompanyName string `json:"company_name"`
+}
+
+// Usage reports token accounting for a single Decide call, when the
+// provider's API exposes it.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Provider decides the next step given a system prompt, the current
+// task/observation rendered by the caller into userPrompt, and the tools
+// available this step.
+type Provider interface {
+	Decide(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (AgentAction, Usage, error)
+}
+
+// StreamingProvider is an optional capability: a Provider implements it
+// when it can report its decision incrementally as it's formed, instead
+// of only once Decide's whole response has arrived. Only OpenAIProvider
+// does, since its tool_calls come over the wire as real deltas; Gemini
+// and Ollama decide from one complete text completion, so there's
+// nothing earlier to report.
+type StreamingProvider interface {
+	// DecideStream streams JSON-encoded AgentAction snapshots on chunks as
+	// more of the decision becomes known — e.g. the chosen tool's name
+	// before its arguments have finished arriving — with the last value
+	// sent being the final decision. It sends at most one error on errCh,
+	// after which both channels are closed.
+	DecideStream(ctx context.Context, systemPrompt, userPrompt string, tools []ToolSpec) (chunks <-chan string, errCh <-chan error)
+}
+
+// ErrRateLimited means the provider asked us to back off before retrying.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited: retry after %s", e.RetryAfter)
+}
+
+// ErrTransient wraps a retryable failure (5xx, network error) so callers
+// can distinguish it from a permanent one (bad request, auth failure).
+type ErrTransient struct {
+	Err error
+}
+
+func (e *ErrTransient) Error() string { return fmt.Sprintf("transient LLM error: %v", e.Err) }
+func (e *ErrTransient) Unwrap() error { return e.Err }
+
+// BackoffWithJitter returns how long to wait before retry attempt n
+// (0-indexed), doubling each time up to a 30s cap with +/-50% jitter so a
+// fleet of retries doesn't thunder in lockstep.
+func BackoffWithJitter(attempt int) time.Duration {
+	const base = 500 * time.Millisecond
+	const max = 30 * time.Second
+
+	if attempt > 6 {
+		attempt = 6
+	}
+	d := base << uint(attempt)
+	if d > max {
+		d = max
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) + 1))
+	return d/2 + jitter/2
+}
+
+// NewProviderFromEnv selects a Provider by LLM_PROVIDER, defaulting to
+// Gemini to preserve existing behavior.
+func NewProviderFromEnv() (Provider, error) {
+	switch p := os.Getenv("LLM_PROVIDER"); p {
+	case "", "gemini":
+		return &GeminiProvider{}, nil
+	case "openai":
+		return &OpenAIProvider{}, nil
+	case "ollama":
+		return &OllamaProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported LLM_PROVIDER %q", p)
+	}
+}
+
+// parseJSONAction extracts the first top-level JSON object out of raw
+// (tolerating prose a model may wrap it in) and normalizes it into an
+// AgentAction. It's shared by providers that decide via a single text
+// completion rather than real function-calling.
+func parseJSONAction(raw string) (AgentAction, error) {
+	raw = strings.TrimSpace(raw)
+
+	if !strings.HasPrefix(raw, "{") {
+		if i := strings.Index(raw, "{"); i >= 0 {
+			if j := strings.LastIndex(raw, "}"); j > i {
+				raw = raw[i : j+1]
+			}
+		}
+	}
+
+	var action AgentAction
+	if err := json.Unmarshal([]byte(raw), &action); err != nil {
+		return AgentAction{}, fmt.Errorf("failed to parse LLM JSON: %v\nraw=%s", err, raw)
+	}
+
+	normalizeAction(&action, raw)
+	finalizeActionFields(&action)
+	return action, nil
+}
+
+func normalizeAction(a *AgentAction, raw string) {
+	toolNames := map[string]bool{
+		"navigate":    true,
+		"click":       true,
+		"type":        true,
+		"scroll":      true,
+		"wait":        true,
+		"observe":     true,
+		"press_enter": true,
+	}
+
+	// Case 1: model returned {"action":"click", ...}
+	if toolNames[a.Action] {
+		if a.Tool == "" {
+			a.Tool = a.Action
+		}
+		a.Action = "tool"
+	}
+
+	// Case 2: model returned {"action":"tool"} but forgot tool field
+	if a.Action == "tool" && a.Tool == "" {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &m); err == nil {
+			// tool может быть в поле "tool"
+			if t, ok := m["tool"].(string); ok {
+				a.Tool = t
+			}
+			// или tool может быть в поле "action"
+			if a.Tool == "" {
+				if t, ok := m["action"].(string); ok && toolNames[t] {
+					a.Tool = t
+				}
+			}
+
+			if a.Arguments == nil {
+				a.Arguments = map[string]interface{}{}
+			}
+			if args, ok := m["args"].(map[string]interface{}); ok {
+				for k, v := range args {
+					a.Arguments[k] = v
+				}
+			}
+			// если модель положила параметры прямо в корень
+			for k, v := range m {
+				if k == "action" || k == "tool" || k == "arguments" || k == "args" || k == "question" || k == "summary" {
+					continue
+				}
+				if _, exists := a.Arguments[k]; !exists {
+					a.Arguments[k] = v
+				}
+			}
+		}
+	}
+}
+
+func finalizeActionFields(a *AgentAction) {
+	if len(a.SummaryRaw) == 0 {
+		return
+	}
+
+	var s string
+	if err := json.Unmarshal(a.SummaryRaw, &s); err == nil {
+		a.Summary = s
+		return
+	}
+
+	var arr []JobItem
+	if err := json.Unmarshal(a.SummaryRaw, &arr); err == nil {
+		if len(a.Results) == 0 {
+			a.Results = arr
+		}
+		a.Summary = fmt.Sprintf("Found %d jobs.", len(arr))
+		return
+	}
+
+	a.Summary = "Done."
+}
+
+// parseRetryAfter pulls a "Please retry in 56.25s" style duration out of a
+// provider error message, used when there's no structured retry-after.
+func parseRetryAfter(msg string) (time.Duration, bool) {
+	idx := strings.Index(msg, "Please retry in ")
+	if idx < 0 {
+		return 0, false
+	}
+	tail := msg[idx+len("Please retry in "):]
+	sIdx := strings.Index(tail, "s")
+	if sIdx <= 0 {
+		return 0, false
+	}
+	numStr := strings.TrimSpace(tail[:sIdx])
+	f, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(f*float64(time.Second)) + 2*time.Second, true
+}
@@ -0,0 +1,91 @@
+package llm
+
+import "testing"
+
+func TestParseJSONActionPlainTool(t *testing.T) {
+	action, err := parseJSONAction(`{"action":"tool","tool":"navigate","arguments":{"url":"https://example.com"}}`)
+	if err != nil {
+		t.Fatalf("parseJSONAction: %v", err)
+	}
+	if action.Action != "tool" || action.Tool != "navigate" {
+		t.Fatalf("action = %+v", action)
+	}
+	if action.Arguments["url"] != "https://example.com" {
+		t.Fatalf("arguments = %v", action.Arguments)
+	}
+}
+
+func TestParseJSONActionStripsSurroundingProse(t *testing.T) {
+	raw := "Sure, here you go:\n```json\n{\"action\":\"finish\",\"summary\":\"done\"}\n```"
+	action, err := parseJSONAction(raw)
+	if err != nil {
+		t.Fatalf("parseJSONAction: %v", err)
+	}
+	if action.Action != "finish" || action.Summary != "done" {
+		t.Fatalf("action = %+v", action)
+	}
+}
+
+func TestParseJSONActionBareToolNameAsAction(t *testing.T) {
+	// Model returned {"action":"click", "arguments":{...}} instead of the
+	// {"action":"tool","tool":"click",...} envelope.
+	action, err := parseJSONAction(`{"action":"click","arguments":{"element_id":3}}`)
+	if err != nil {
+		t.Fatalf("parseJSONAction: %v", err)
+	}
+	if action.Action != "tool" || action.Tool != "click" {
+		t.Fatalf("action = %+v", action)
+	}
+	if action.Arguments["element_id"] != float64(3) {
+		t.Fatalf("arguments = %v", action.Arguments)
+	}
+}
+
+func TestParseJSONActionMissingToolFieldRecoveredFromArgs(t *testing.T) {
+	// Model returned {"action":"tool", ...} without a "tool" field at all;
+	// normalizeAction still salvages whatever arguments it can find under
+	// "args" (or at the root) even though it can't recover the tool name.
+	action, err := parseJSONAction(`{"action":"tool","args":{"element_id":1,"text":"hi"}}`)
+	if err != nil {
+		t.Fatalf("parseJSONAction: %v", err)
+	}
+	if action.Arguments["element_id"] != float64(1) || action.Arguments["text"] != "hi" {
+		t.Fatalf("arguments = %v", action.Arguments)
+	}
+}
+
+func TestFinalizeActionFieldsArrayResults(t *testing.T) {
+	action := AgentAction{SummaryRaw: []byte(`[{"job_title":"Engineer","company_name":"Acme"}]`)}
+	finalizeActionFields(&action)
+
+	if len(action.Results) != 1 || action.Results[0].JobTitle != "Engineer" {
+		t.Fatalf("results = %+v", action.Results)
+	}
+	if action.Summary != "Found 1 jobs." {
+		t.Fatalf("summary = %q", action.Summary)
+	}
+}
+
+func TestFinalizeActionFieldsStringSummary(t *testing.T) {
+	action := AgentAction{SummaryRaw: []byte(`"all done"`)}
+	finalizeActionFields(&action)
+
+	if action.Summary != "all done" {
+		t.Fatalf("summary = %q", action.Summary)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("RESOURCE_EXHAUSTED: Please retry in 56.25s due to quota")
+	if !ok {
+		t.Fatal("expected to parse retry duration")
+	}
+	// parseRetryAfter adds a 2s buffer on top of the provider's hint.
+	if d.Seconds() != 58.25 {
+		t.Fatalf("duration = %v, want 58.25s", d)
+	}
+
+	if _, ok := parseRetryAfter("some other error"); ok {
+		t.Fatal("expected no retry duration to be found")
+	}
+}
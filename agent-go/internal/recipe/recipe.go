@@ -0,0 +1,311 @@
+// Package recipe implements named, parameterized multi-step playbooks
+// that the agent can invoke as a single tool call (e.g. "recipe.hh_search")
+// instead of deciding every primitive action itself.
+package recipe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/apps"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/worker"
+)
+
+func init() {
+	apps.Register(15, "recipes", func(ctx context.Context, h *service.Harness) error {
+		reg, err := LoadRegistry("../recipes")
+		if err != nil {
+			return err
+		}
+		h.Add("recipes", reg)
+		return nil
+	})
+}
+
+// ToolPrefix marks a tool name as a recipe invocation rather than a
+// primitive worker call, e.g. "recipe.hh_search".
+const ToolPrefix = "recipe."
+
+// Condition describes what to wait for after a step runs before the
+// recipe is allowed to move on. A step blocks, re-observing the page,
+// until the condition holds or TimeoutMS elapses.
+type Condition struct {
+	URLMatches   string `json:"url_matches,omitempty"`
+	TextContains string `json:"text_contains,omitempty"`
+	TimeoutMS    int    `json:"timeout_ms,omitempty"`
+}
+
+// Branch runs a nested recipe when Condition holds against the
+// observation left by the step it's attached to, e.g. falling back to a
+// different search area when the results page reports no matches.
+type Branch struct {
+	Condition Condition              `json:"condition"`
+	Recipe    string                 `json:"recipe"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// Step is either a primitive worker call (Tool) or a nested recipe
+// invocation (Recipe). Arguments may reference params via "{{name}}".
+type Step struct {
+	Tool      string                 `json:"tool,omitempty"`
+	Recipe    string                 `json:"recipe,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Until     *Condition             `json:"until,omitempty"`
+	Branches  []Branch               `json:"branches,omitempty"`
+}
+
+// Recipe is a named, parameterized playbook: an ordered list of steps.
+type Recipe struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+	Steps  []Step   `json:"steps"`
+}
+
+// Registry holds recipes loaded from a directory at startup.
+type Registry struct {
+	recipes map[string]*Recipe
+}
+
+// LoadRegistry reads every *.json file in dir as a Recipe. A missing
+// directory yields an empty, usable registry rather than an error, since
+// recipes are optional.
+func LoadRegistry(dir string) (*Registry, error) {
+	reg := &Registry{recipes: map[string]*Recipe{}}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return reg, nil
+		}
+		return nil, fmt.Errorf("reading recipes dir %s: %w", dir, err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading recipe %s: %w", path, err)
+		}
+		var r Recipe
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, fmt.Errorf("parsing recipe %s: %w", path, err)
+		}
+		if r.Name == "" {
+			return nil, fmt.Errorf("recipe %s: missing name", path)
+		}
+		reg.recipes[r.Name] = &r
+	}
+
+	return reg, nil
+}
+
+// IsRecipeTool reports whether tool names a recipe invocation, and if so
+// returns the bare recipe name.
+func IsRecipeTool(tool string) (string, bool) {
+	if !strings.HasPrefix(tool, ToolPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(tool, ToolPrefix), true
+}
+
+// Names returns the loaded recipe names, sorted for stable prompt output.
+func (reg *Registry) Names() []string {
+	names := make([]string, 0, len(reg.recipes))
+	for n := range reg.recipes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToolDescriptions renders one line per recipe, suitable for appending to
+// the system prompt so the LLM can see recipes alongside primitive tools.
+func (reg *Registry) ToolDescriptions() string {
+	if len(reg.recipes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\nRecipes (multi-step playbooks, invoke like a tool):\n")
+	for _, name := range reg.Names() {
+		r := reg.recipes[name]
+		b.WriteString(fmt.Sprintf("- %s%s(%s)\n", ToolPrefix, r.Name, strings.Join(r.Params, ", ")))
+	}
+	return b.String()
+}
+
+// ToolSpec describes one recipe-backed tool: its name and params. Callers
+// that build an llm.ToolSpec list for a real function-calling provider can
+// turn this into an object schema themselves.
+type ToolSpec struct {
+	Name   string
+	Params []string
+}
+
+// ToolSpecs returns one ToolSpec per loaded recipe, name-prefixed so it can
+// be dispatched through IsRecipeTool/Run, sorted for stable output.
+func (reg *Registry) ToolSpecs() []ToolSpec {
+	specs := make([]ToolSpec, 0, len(reg.recipes))
+	for _, name := range reg.Names() {
+		r := reg.recipes[name]
+		specs = append(specs, ToolSpec{Name: ToolPrefix + r.Name, Params: r.Params})
+	}
+	return specs
+}
+
+// SendFunc dispatches a single primitive worker call. Callers pass a
+// closure that applies per-tool timeouts via worker.BrowserWorker.SendCtx.
+type SendFunc func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error)
+
+// Run executes the named recipe, substituting params into each step's
+// arguments and dispatching primitive steps through send or nested
+// recipes through a recursive Run. After a step's Until wait (if any),
+// its Branches are checked in order and the first one whose condition
+// matches the resulting observation is run as a nested recipe in place
+// of just moving on — e.g. falling back to a different search area when
+// the page reports no results. It returns the last observation seen.
+func (reg *Registry) Run(ctx context.Context, send SendFunc, name string, params map[string]interface{}) (worker.Resp, error) {
+	r, ok := reg.recipes[name]
+	if !ok {
+		return worker.Resp{}, fmt.Errorf("unknown recipe: %s", name)
+	}
+	for _, p := range r.Params {
+		if _, ok := params[p]; !ok {
+			return worker.Resp{}, fmt.Errorf("recipe %s: missing required param %q", name, p)
+		}
+	}
+
+	var last worker.Resp
+	for i, step := range r.Steps {
+		args := substituteParams(step.Arguments, params)
+
+		var resp worker.Resp
+		var err error
+		switch {
+		case step.Recipe != "":
+			resp, err = reg.Run(ctx, send, step.Recipe, args)
+		case step.Tool != "":
+			resp, err = send(ctx, step.Tool, args)
+		default:
+			return worker.Resp{}, fmt.Errorf("recipe %s: step %d has neither tool nor recipe", name, i)
+		}
+		if err != nil {
+			return worker.Resp{}, fmt.Errorf("recipe %s: step %d: %w", name, i, err)
+		}
+		if resp.Status == "error" {
+			return resp, fmt.Errorf("recipe %s: step %d returned error: %s", name, i, resp.Message)
+		}
+		last = resp
+
+		if step.Until != nil {
+			resp, err = reg.waitUntil(ctx, send, *step.Until, resp)
+			if err != nil {
+				return resp, fmt.Errorf("recipe %s: step %d: %w", name, i, err)
+			}
+			last = resp
+		}
+
+		for _, branch := range step.Branches {
+			if !conditionMet(branch.Condition, last.Observation) {
+				continue
+			}
+			branchArgs := substituteParams(branch.Arguments, params)
+			resp, err = reg.Run(ctx, send, branch.Recipe, branchArgs)
+			if err != nil {
+				return resp, fmt.Errorf("recipe %s: step %d: branch %s: %w", name, i, branch.Recipe, err)
+			}
+			last = resp
+			break
+		}
+	}
+
+	return last, nil
+}
+
+// waitUntil polls the worker's current observation until cond is
+// satisfied or its timeout elapses, starting from an observation the
+// caller already has in hand.
+func (reg *Registry) waitUntil(ctx context.Context, send SendFunc, cond Condition, obs worker.Resp) (worker.Resp, error) {
+	if conditionMet(cond, obs.Observation) {
+		return obs, nil
+	}
+
+	timeout := time.Duration(cond.TimeoutMS) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(300 * time.Millisecond)
+
+		resp, err := send(ctx, "observe", map[string]interface{}{})
+		if err != nil {
+			return obs, err
+		}
+		if resp.Status == "error" {
+			return resp, fmt.Errorf("observe failed while waiting: %s", resp.Message)
+		}
+		obs = resp
+		if conditionMet(cond, obs.Observation) {
+			return obs, nil
+		}
+	}
+
+	return obs, fmt.Errorf("timed out waiting for condition (url_matches=%q text_contains=%q)", cond.URLMatches, cond.TextContains)
+}
+
+func conditionMet(cond Condition, observation map[string]interface{}) bool {
+	if cond.URLMatches != "" {
+		u, _ := observation["url"].(string)
+		ok, err := regexp.MatchString(cond.URLMatches, u)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	if cond.TextContains != "" {
+		vt, _ := observation["visible_text"].(string)
+		if !strings.Contains(vt, cond.TextContains) {
+			return false
+		}
+	}
+	return cond.URLMatches != "" || cond.TextContains != ""
+}
+
+// substituteParams replaces "{{name}}" references in a step's arguments
+// with the caller-supplied params, preserving non-string values untouched.
+func substituteParams(args map[string]interface{}, params map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		out[k] = substituteValue(v, params)
+	}
+	return out
+}
+
+func substituteValue(v interface{}, params map[string]interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	for k, pv := range params {
+		token := "{{" + k + "}}"
+		if s == token {
+			return pv
+		}
+		if strings.Contains(s, token) {
+			s = strings.ReplaceAll(s, token, fmt.Sprint(pv))
+		}
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package recipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/worker"
+)
+
+func TestRunSubstitutesParamsIntoArguments(t *testing.T) {
+	reg := &Registry{recipes: map[string]*Recipe{
+		"greet": {
+			Name:   "greet",
+			Params: []string{"name"},
+			Steps: []Step{
+				{Tool: "type", Arguments: map[string]interface{}{"text": "hello {{name}}"}},
+			},
+		},
+	}}
+
+	var got map[string]interface{}
+	send := func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error) {
+		got = args
+		return worker.Resp{Status: "ok"}, nil
+	}
+
+	if _, err := reg.Run(context.Background(), send, "greet", map[string]interface{}{"name": "world"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got["text"] != "hello world" {
+		t.Fatalf("text = %q, want %q", got["text"], "hello world")
+	}
+}
+
+func TestRunBranchDispatchesOnCondition(t *testing.T) {
+	reg := &Registry{recipes: map[string]*Recipe{
+		"search": {
+			Name:   "search",
+			Params: []string{"query"},
+			Steps: []Step{
+				{
+					Tool:      "navigate",
+					Arguments: map[string]interface{}{"url": "https://example.com?q={{query}}"},
+					Branches: []Branch{
+						{
+							Condition: Condition{TextContains: "no results"},
+							Recipe:    "retry",
+							Arguments: map[string]interface{}{"query": "{{query}}"},
+						},
+					},
+				},
+			},
+		},
+		"retry": {
+			Name:   "retry",
+			Params: []string{"query"},
+			Steps: []Step{
+				{Tool: "navigate", Arguments: map[string]interface{}{"url": "https://example.com/retry?q={{query}}"}},
+			},
+		},
+	}}
+
+	var navigated []string
+	send := func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error) {
+		url, _ := args["url"].(string)
+		navigated = append(navigated, url)
+		return worker.Resp{Status: "ok", Observation: map[string]interface{}{"visible_text": "no results"}}, nil
+	}
+
+	if _, err := reg.Run(context.Background(), send, "search", map[string]interface{}{"query": "go"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{"https://example.com?q=go", "https://example.com/retry?q=go"}
+	if len(navigated) != len(want) {
+		t.Fatalf("navigated = %v, want %v", navigated, want)
+	}
+	for i := range want {
+		if navigated[i] != want[i] {
+			t.Fatalf("navigated[%d] = %q, want %q", i, navigated[i], want[i])
+		}
+	}
+}
+
+func TestRunMissingRequiredParamErrors(t *testing.T) {
+	reg := &Registry{recipes: map[string]*Recipe{
+		"greet": {
+			Name:   "greet",
+			Params: []string{"name"},
+			Steps: []Step{
+				{Tool: "type", Arguments: map[string]interface{}{"text": "hello {{name}}"}},
+			},
+		},
+	}}
+
+	var called bool
+	send := func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error) {
+		called = true
+		return worker.Resp{Status: "ok"}, nil
+	}
+
+	_, err := reg.Run(context.Background(), send, "greet", map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected an error for a missing required param")
+	}
+	if called {
+		t.Fatal("send should not have been called when a required param is missing")
+	}
+}
+
+func TestRunBranchNotTakenWhenConditionUnmet(t *testing.T) {
+	reg := &Registry{recipes: map[string]*Recipe{
+		"search": {
+			Name: "search",
+			Steps: []Step{
+				{
+					Tool: "navigate",
+					Branches: []Branch{
+						{Condition: Condition{TextContains: "no results"}, Recipe: "retry"},
+					},
+				},
+			},
+		},
+		"retry": {
+			Name:  "retry",
+			Steps: []Step{{Tool: "navigate"}},
+		},
+	}}
+
+	var calls int
+	send := func(ctx context.Context, tool string, args map[string]interface{}) (worker.Resp, error) {
+		calls++
+		return worker.Resp{Status: "ok", Observation: map[string]interface{}{"visible_text": "3 jobs found"}}, nil
+	}
+
+	if _, err := reg.Run(context.Background(), send, "search", nil); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1 (branch should not have run)", calls)
+	}
+}
@@ -0,0 +1,34 @@
+// Package service provides the Harness that registered apps publish their
+// components into (LLM provider, browser worker, extractors, ...) so
+// downstream packages like agent can pull their dependencies from one
+// place instead of constructing them directly.
+package service
+
+import "sync"
+
+// Harness is a small, named component registry built up by apps.RunAll
+// and read by whatever assembles the final program (main, agent.Run).
+type Harness struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+// New returns an empty Harness.
+func New() *Harness {
+	return &Harness{values: map[string]any{}}
+}
+
+// Add publishes a component under name, overwriting any previous value.
+func (h *Harness) Add(name string, v any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.values[name] = v
+}
+
+// Get retrieves a previously-added component by name.
+func (h *Harness) Get(name string) (any, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	v, ok := h.values[name]
+	return v, ok
+}
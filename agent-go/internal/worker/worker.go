@@ -0,0 +1,323 @@
+// Package worker runs and talks to the Python browser worker over stdio,
+// behind a BrowserWorker interface so a future backend (e.g. a Playwright-Go
+// driver) can stand in for it without touching the agent loop.
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/apps"
+	"github.com/Dias221467/AI-Agent-LLM_beta/agent-go/internal/service"
+)
+
+func init() {
+	apps.Register(20, "worker", func(ctx context.Context, h *service.Harness) error {
+		w, err := StartPyWorker()
+		if err != nil {
+			return err
+		}
+		h.Add("worker", BrowserWorker(w))
+		return nil
+	})
+}
+
+// Cmd is the JSON request frame sent to the worker's stdin.
+type Cmd struct {
+	Action string                 `json:"action"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+// Resp is the JSON reply frame read from the worker's stdout.
+type Resp struct {
+	Status      string                 `json:"status"`
+	Message     string                 `json:"message,omitempty"`
+	Observation map[string]interface{} `json:"observation,omitempty"`
+}
+
+// BrowserWorker is whatever can execute a primitive browser action and
+// return the resulting observation. PyWorker is the only implementation
+// today; a Playwright-Go backend would satisfy the same interface.
+type BrowserWorker interface {
+	Send(action string, args map[string]interface{}) (Resp, error)
+	SendCtx(ctx context.Context, action string, args map[string]interface{}) (Resp, error)
+	// SetDeadline arms (or, with a zero Time, disarms) a wall-clock ceiling
+	// that every subsequent SendCtx call is bound by in addition to its own
+	// ctx, for callers that want to cap total worker time across many
+	// calls rather than any single one of them.
+	SetDeadline(t time.Time)
+	Stop()
+}
+
+// PyWorker drives ../browser-worker/worker.py as a long-lived child
+// process, exchanging newline-delimited JSON over its stdio.
+//
+// stdin/stdout are only ever replaced under mu, by restart, so a SendCtx
+// call that's about to read them takes a local copy while holding the
+// lock; it never touches w.stdout/w.stdin directly after that point.
+//
+// callMu serializes whole SendCtx calls: the child process handles one
+// command at a time over a single stdin/stdout pair, so two calls in
+// flight together would each read whichever reply line lands next,
+// regardless of who sent the command it answers. Callers that need to run
+// several tasks against one worker (e.g. the HTTP API's concurrent
+// /tasks) queue here rather than interleave.
+type PyWorker struct {
+	callMu sync.Mutex
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+
+	deadlineMu sync.Mutex
+	deadline   time.Time
+}
+
+// newPyWorkerCmd builds the child-process command. It's a var, not a
+// literal inline in launchPyWorker, so tests can substitute a fake child
+// (e.g. a self-exec'd test helper) without a real Python interpreter.
+var newPyWorkerCmd = func() *exec.Cmd {
+	cmd := exec.Command("python", "worker.py")
+	cmd.Dir = "../browser-worker"
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// startupHandshakeTimeout/restartHandshakeTimeout bound how long
+// launchPyWorker will wait for the child's ready line. They're vars, not
+// consts, so tests can shrink them instead of waiting out the real value.
+var (
+	startupHandshakeTimeout = 30 * time.Second
+	restartHandshakeTimeout = 15 * time.Second
+)
+
+// StartPyWorker launches the worker and waits for its ready handshake.
+func StartPyWorker() (*PyWorker, error) {
+	cmd, stdin, stdout, err := launchPyWorker(startupHandshakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &PyWorker{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+// launchPyWorker starts the child process and performs the ready handshake,
+// returning the pieces a PyWorker needs. It's factored out of StartPyWorker
+// so restart can relaunch a fresh child with the same setup.
+//
+// The handshake read is bounded by handshakeTimeout rather than blocking
+// forever: restart calls this while callMu is still held by the SendCtx
+// call whose timeout triggered it, so a child that starts but never prints
+// its ready line (broken env, slow interpreter start, crash-loop) must
+// still make launchPyWorker return an error instead of wedging every
+// future SendCtx call behind callMu for the rest of the process's life.
+func launchPyWorker(handshakeTimeout time.Duration) (*exec.Cmd, io.WriteCloser, *bufio.Reader, error) {
+	cmd := newPyWorkerCmd()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	stdout := bufio.NewReader(stdoutPipe)
+
+	hello, err := readHandshake(stdout, handshakeTimeout)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, nil, nil, err
+	}
+	if hello.Status != "ok" {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, nil, nil, fmt.Errorf("worker did not start: %s", hello.Message)
+	}
+
+	fmt.Println("✅ Python worker started")
+	return cmd, stdin, stdout, nil
+}
+
+// readHandshake reads the child's ready line with the same
+// goroutine-plus-select shape SendCtx uses for replies, so a child that
+// never writes one can't block its caller past timeout. The read goroutine
+// that's left behind on timeout unblocks once the caller kills the child
+// and its stdout pipe closes, the same way a timed-out SendCtx read does.
+func readHandshake(stdout *bufio.Reader, timeout time.Duration) (Resp, error) {
+	type readResult struct {
+		line string
+		err  error
+	}
+	resultCh := make(chan readResult, 1)
+	go func() {
+		line, err := stdout.ReadString('\n')
+		resultCh <- readResult{line: line, err: err}
+	}()
+
+	select {
+	case r := <-resultCh:
+		if r.err != nil {
+			return Resp{}, r.err
+		}
+		line := strings.TrimSpace(r.line)
+		var hello Resp
+		if err := json.Unmarshal([]byte(line), &hello); err != nil {
+			return Resp{}, fmt.Errorf("failed to parse worker hello: %v, line=%s", err, line)
+		}
+		return hello, nil
+	case <-time.After(timeout):
+		return Resp{}, fmt.Errorf("worker handshake timed out after %v", timeout)
+	}
+}
+
+// Send issues action/args and blocks until the worker replies. It is a
+// thin wrapper around SendCtx for call sites that don't need a deadline.
+func (w *PyWorker) Send(action string, args map[string]interface{}) (Resp, error) {
+	return w.SendCtx(context.Background(), action, args)
+}
+
+// SendCtx issues action/args and waits for the worker's reply, the same as
+// Send, but also gives up as soon as ctx is done. The pipe read happens in
+// a goroutine so a hung child process can't block the caller forever.
+//
+// On timeout the read goroutine is left running against the stdout it
+// captured above, but that pipe belongs to a child we're about to kill: once
+// restart tears the process down, the pipe closes out from under the
+// goroutine's blocked Read, which returns an error and the goroutine exits.
+// That's what keeps a timed-out read from lingering to race the next
+// SendCtx's read of the (now-replaced) w.stdout.
+func (w *PyWorker) SendCtx(ctx context.Context, action string, args map[string]interface{}) (Resp, error) {
+	w.callMu.Lock()
+	defer w.callMu.Unlock()
+
+	if deadline := w.currentDeadline(); !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	w.mu.Lock()
+	stdin := w.stdin
+	stdout := w.stdout
+	w.mu.Unlock()
+
+	cmdObj := Cmd{
+		Action: action,
+		Args:   args,
+	}
+	data, err := json.Marshal(cmdObj)
+	if err != nil {
+		return Resp{}, err
+	}
+
+	if _, err := stdin.Write(append(data, '\n')); err != nil {
+		return Resp{}, err
+	}
+
+	type readResult struct {
+		resp Resp
+		err  error
+	}
+	resultCh := make(chan readResult, 1)
+	go func() {
+		line, err := stdout.ReadString('\n')
+		if err != nil {
+			resultCh <- readResult{err: err}
+			return
+		}
+		line = strings.TrimSpace(line)
+
+		var resp Resp
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			resultCh <- readResult{err: fmt.Errorf("failed to parse worker response: %v, line=%s", err, line)}
+			return
+		}
+		resultCh <- readResult{resp: resp}
+	}()
+
+	select {
+	case r := <-resultCh:
+		return r.resp, r.err
+	case <-ctx.Done():
+		if restartErr := w.restart(); restartErr != nil {
+			return Resp{}, fmt.Errorf("worker timeout: %w (restart also failed: %v)", ctx.Err(), restartErr)
+		}
+		return Resp{}, fmt.Errorf("worker timeout: %w", ctx.Err())
+	}
+}
+
+// SetDeadline arms a wall-clock ceiling applied to every SendCtx call from
+// here on, alongside whatever ctx each call already carries; a zero Time
+// disarms it. See BrowserWorker for why this exists separately from a
+// per-call ctx timeout.
+func (w *PyWorker) SetDeadline(t time.Time) {
+	w.deadlineMu.Lock()
+	w.deadline = t
+	w.deadlineMu.Unlock()
+}
+
+func (w *PyWorker) currentDeadline() time.Time {
+	w.deadlineMu.Lock()
+	defer w.deadlineMu.Unlock()
+	return w.deadline
+}
+
+// restart kills the current child process and replaces it with a fresh one,
+// discarding whatever request was in flight. This is how we recover stdio
+// framing after a timeout: resyncing in place would still leave the old
+// read goroutine sharing the same bufio.Reader as the next SendCtx call,
+// since bufio.Reader isn't safe for concurrent use. Killing the process
+// instead closes its stdout out from under that goroutine, and the
+// replacement PyWorker state has its own stdin/stdout the old goroutine
+// never touches.
+//
+// It's called by SendCtx while callMu is still held by the very call that
+// timed out, so launchPyWorker's own bounded handshake timeout is what
+// keeps a child that never comes up healthy from wedging callMu — and
+// every SendCtx call behind it — forever; restart itself does no
+// unbounded waiting of its own.
+func (w *PyWorker) restart() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cmd != nil && w.cmd.Process != nil {
+		_ = w.cmd.Process.Kill()
+		_ = w.cmd.Wait()
+	}
+
+	cmd, stdin, stdout, err := launchPyWorker(restartHandshakeTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "worker: failed to restart after timeout: %v\n", err)
+		return err
+	}
+	w.cmd = cmd
+	w.stdin = stdin
+	w.stdout = stdout
+	return nil
+}
+
+// Stop asks the worker to exit and then kills the child process.
+func (w *PyWorker) Stop() {
+	_, _ = w.Send("exit", map[string]interface{}{})
+	w.mu.Lock()
+	cmd := w.cmd
+	w.mu.Unlock()
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// TestHelperProcess isn't a real test: it's a child process body, spawned
+// by tests below via exec.Command(os.Args[0], ...) under
+// GO_WANT_HELPER_PROCESS=1, standing in for ../browser-worker/worker.py so
+// these tests don't need a real Python interpreter. Its behavior is picked
+// by the HELPER_MODE env var.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	switch os.Getenv("HELPER_MODE") {
+	case "hang_after_hello":
+		fmt.Println(`{"status":"ok"}`)
+		// Read commands but never answer one: a real blocking syscall, so
+		// it just sits there until killed instead of tripping Go's
+		// runtime deadlock detector the way an idle select{} would.
+		_, _ = io.Copy(io.Discard, os.Stdin)
+
+	case "slow_hello":
+		time.Sleep(2 * time.Second)
+		fmt.Println(`{"status":"ok"}`)
+		runEchoLoop()
+
+	case "normal":
+		fmt.Println(`{"status":"ok"}`)
+		runEchoLoop()
+	}
+}
+
+// runEchoLoop answers every command frame with a trivial ok reply, enough
+// to drive a real SendCtx round trip against the fake child.
+func runEchoLoop() {
+	in := bufio.NewReader(os.Stdin)
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return
+		}
+		_ = line
+		fmt.Println(`{"status":"ok"}`)
+	}
+}
+
+func helperCmd(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "HELPER_MODE="+mode)
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// withHelperCmd swaps newPyWorkerCmd for a fake child for the duration of
+// a test, and restores it afterwards.
+func withHelperCmd(t *testing.T, mode string) {
+	t.Helper()
+	prev := newPyWorkerCmd
+	newPyWorkerCmd = func() *exec.Cmd { return helperCmd(mode) }
+	t.Cleanup(func() { newPyWorkerCmd = prev })
+}
+
+// withHelperCmdSequence is withHelperCmd for a test that spawns more than
+// one child (the first one for StartPyWorker, later ones via restart):
+// each successive launchPyWorker call gets the next mode in modes, with
+// the last mode repeating for any call beyond the list.
+func withHelperCmdSequence(t *testing.T, modes ...string) {
+	t.Helper()
+	prev := newPyWorkerCmd
+	var n int
+	newPyWorkerCmd = func() *exec.Cmd {
+		mode := modes[n]
+		if n < len(modes)-1 {
+			n++
+		}
+		return helperCmd(mode)
+	}
+	t.Cleanup(func() { newPyWorkerCmd = prev })
+}
+
+func TestSendCtxTimeoutRestartsWorker(t *testing.T) {
+	withHelperCmdSequence(t, "hang_after_hello", "normal")
+	prevRestartTimeout := restartHandshakeTimeout
+	restartHandshakeTimeout = 2 * time.Second
+	t.Cleanup(func() { restartHandshakeTimeout = prevRestartTimeout })
+
+	w, err := StartPyWorker()
+	if err != nil {
+		t.Fatalf("StartPyWorker: %v", err)
+	}
+	defer w.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err = w.SendCtx(ctx, "noop", nil)
+	if err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+
+	// The restarted child should have come up healthy (its handshake
+	// arrives well under restartHandshakeTimeout), so this call must not
+	// hang and must succeed.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := w.Send("noop", nil); err != nil {
+			t.Errorf("Send after restart: %v", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send after restart did not return: worker appears wedged")
+	}
+}
+
+// TestRestartHandshakeTimeoutReturnsError is the regression test for the
+// bug this fix addresses: a restart whose freshly-spawned child never (or
+// too slowly) prints its ready line must return an error of its own,
+// bounded by restartHandshakeTimeout, rather than blocking restart — and
+// therefore every SendCtx call queued behind callMu — forever.
+func TestRestartHandshakeTimeoutReturnsError(t *testing.T) {
+	withHelperCmd(t, "slow_hello")
+	restartHandshakeTimeout = 200 * time.Millisecond
+	t.Cleanup(func() { restartHandshakeTimeout = 15 * time.Second })
+
+	w := &PyWorker{}
+
+	done := make(chan error, 1)
+	go func() { done <- w.restart() }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected restart to fail when the child's handshake is slower than restartHandshakeTimeout")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("restart did not return: handshake read is not bounded by restartHandshakeTimeout")
+	}
+}